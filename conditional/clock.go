@@ -0,0 +1,59 @@
+package conditional
+
+import "time"
+
+// Timer is the interface satisfied by the timers returned by a Clock. It
+// mirrors the subset of *time.Timer used by the conditional package.
+type Timer interface {
+	// C returns the channel on which the timer delivers its tick.
+	C() <-chan time.Time
+
+	// Stop prevents the timer from firing. It returns false if the timer
+	// already expired or was already stopped.
+	Stop() bool
+
+	// Reset changes the timer to expire after duration d. It returns false
+	// if the timer already expired or was already stopped.
+	Reset(d time.Duration) bool
+}
+
+// Clock abstracts time so that timed conditions (Delay, Poll...) can be
+// driven deterministically in tests. See the conditionaltest package for a
+// FakeClock implementation.
+type Clock interface {
+	// NewTimer creates a Timer that sends the current time on its channel
+	// after at least duration d.
+	NewTimer(d time.Duration) Timer
+
+	// Now returns the current time.
+	Now() time.Time
+}
+
+// RealClock is the Clock backed by the standard `time` package.
+var RealClock Clock = realClock{}
+
+type realClock struct{}
+
+func (realClock) NewTimer(d time.Duration) Timer {
+	return &realTimer{timer: time.NewTimer(d)}
+}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+type realTimer struct {
+	timer *time.Timer
+}
+
+func (t *realTimer) C() <-chan time.Time {
+	return t.timer.C
+}
+
+func (t *realTimer) Stop() bool {
+	return t.timer.Stop()
+}
+
+func (t *realTimer) Reset(d time.Duration) bool {
+	return t.timer.Reset(d)
+}
@@ -0,0 +1,71 @@
+package conditional
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrConditionClosed is returned on a Wait or GetAndWaitChange channel (or
+// from their context-aware counterparts) when the condition is closed while
+// the wait is still pending.
+var ErrConditionClosed = errors.New("conditional: condition closed")
+
+// ConditionStateObserver is implemented by types that want to be notified
+// whenever a Condition's satisfied state changes.
+type ConditionStateObserver interface {
+	// OnChange is called with the new satisfied state.
+	OnChange(satisfied bool)
+}
+
+// Condition represents an observable boolean state that can be waited upon,
+// observed and closed.
+type Condition interface {
+	// Wait returns a channel that blocks until the condition reaches the
+	// specified satisfied state.
+	//
+	// If the condition already has the satisfied state at the moment of the
+	// call, a closed channel is returned (which won't block).
+	//
+	// If the condition is closed or the wait fails for whatever reason,
+	// ErrConditionClosed is returned on the channel.
+	Wait(satisfied bool) <-chan error
+
+	// WaitCtx behaves like Wait, but also unblocks and returns ctx.Err() as
+	// soon as ctx is done, without closing the condition itself.
+	WaitCtx(ctx context.Context, satisfied bool) error
+
+	// GetAndWaitChange returns the current satisfied state of the condition
+	// as well as a channel that blocks until the condition state changes.
+	//
+	// If the condition is closed or the wait fails for whatever reason,
+	// ErrConditionClosed is returned on the channel.
+	GetAndWaitChange() (bool, <-chan error)
+
+	// GetAndWaitChangeCtx behaves like GetAndWaitChange, but also unblocks
+	// and returns ctx.Err() as soon as ctx is done, without closing the
+	// condition itself.
+	GetAndWaitChangeCtx(ctx context.Context) (bool, error)
+
+	// Register an observer for changes.
+	//
+	// Any change will cause the following observer to be called with the
+	// current state until the returned cancel function is called.
+	Register(observer ConditionStateObserver) func()
+
+	// Close terminates the condition.
+	//
+	// Any pending wait on one of the returned channels via Wait() or
+	// GetAndWaitChange() will be unblocked.
+	//
+	// Calling Close() twice or more has no effect.
+	Close() error
+}
+
+// ConditionStateObserverFunc adapts a plain function to a
+// ConditionStateObserver.
+type ConditionStateObserverFunc func(satisfied bool)
+
+// OnChange calls f.
+func (f ConditionStateObserverFunc) OnChange(satisfied bool) {
+	f(satisfied)
+}
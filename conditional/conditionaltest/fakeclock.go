@@ -0,0 +1,118 @@
+// Package conditionaltest provides test doubles for the conditional
+// package, chiefly a FakeClock that lets timed conditions (Delay, Poll...)
+// be driven deterministically instead of relying on real sleeps.
+package conditionaltest
+
+import (
+	"sync"
+	"time"
+
+	"github.com/intelux/gotomatic/conditional"
+)
+
+// FakeClock is a conditional.Clock whose notion of time only moves forward
+// when Advance is called.
+type FakeClock struct {
+	lock   sync.Mutex
+	now    time.Time
+	timers []*fakeTimer
+}
+
+// NewFakeClock creates a FakeClock whose current time is initially now.
+func NewFakeClock(now time.Time) *FakeClock {
+	return &FakeClock{now: now}
+}
+
+// Now returns the clock's current fake time.
+func (c *FakeClock) Now() time.Time {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.now
+}
+
+// NewTimer creates a conditional.Timer that fires once the fake clock has
+// been advanced to or past its deadline.
+func (c *FakeClock) NewTimer(d time.Duration) conditional.Timer {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	t := &fakeTimer{
+		clock:   c,
+		channel: make(chan time.Time, 1),
+		when:    c.now.Add(d),
+	}
+
+	c.timers = append(c.timers, t)
+
+	return t
+}
+
+// Advance moves the fake clock forward by d, firing any live timer whose
+// deadline has been reached.
+func (c *FakeClock) Advance(d time.Duration) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.now = c.now.Add(d)
+
+	for _, t := range c.timers {
+		if !t.stopped && !t.fired && !t.when.After(c.now) {
+			t.fired = true
+			t.channel <- c.now
+		}
+	}
+}
+
+// Waiters returns the number of timers currently scheduled and still
+// pending (neither fired nor stopped). It is primarily useful in tests, to
+// avoid advancing the clock before the code under test has had a chance to
+// start the timer it is waiting on.
+func (c *FakeClock) Waiters() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	count := 0
+
+	for _, t := range c.timers {
+		if !t.stopped && !t.fired {
+			count++
+		}
+	}
+
+	return count
+}
+
+type fakeTimer struct {
+	clock   *FakeClock
+	channel chan time.Time
+	when    time.Time
+	fired   bool
+	stopped bool
+}
+
+func (t *fakeTimer) C() <-chan time.Time {
+	return t.channel
+}
+
+func (t *fakeTimer) Stop() bool {
+	t.clock.lock.Lock()
+	defer t.clock.lock.Unlock()
+
+	active := !t.stopped && !t.fired
+	t.stopped = true
+
+	return active
+}
+
+func (t *fakeTimer) Reset(d time.Duration) bool {
+	t.clock.lock.Lock()
+	defer t.clock.lock.Unlock()
+
+	active := !t.stopped && !t.fired
+	t.stopped = false
+	t.fired = false
+	t.when = t.clock.now.Add(d)
+
+	return active
+}
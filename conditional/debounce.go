@@ -0,0 +1,25 @@
+package conditional
+
+import "time"
+
+// DebounceAsymmetric returns a Condition behaving like Delay, but applying a
+// different hold time depending on the direction of the transition:
+// riseDelay once the underlying condition becomes satisfied, fallDelay once
+// it becomes unsatisfied. This is a common hysteresis pattern (e.g. a fast
+// alarm-on, slow alarm-clear) that is awkward to build on top of Delay
+// alone, since Delay only supports a single, symmetric hold time.
+func DebounceAsymmetric(condition Condition, riseDelay, fallDelay time.Duration) Condition {
+	return DebounceAsymmetricWithClock(condition, riseDelay, fallDelay, RealClock)
+}
+
+// DebounceAsymmetricWithClock behaves like DebounceAsymmetric, but lets the
+// caller supply the Clock used to schedule the hold times.
+func DebounceAsymmetricWithClock(condition Condition, riseDelay, fallDelay time.Duration, clock Clock) Condition {
+	return newDelayedCondition(condition, func(satisfied bool) time.Duration {
+		if satisfied {
+			return riseDelay
+		}
+
+		return fallDelay
+	}, clock)
+}
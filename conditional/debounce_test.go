@@ -0,0 +1,53 @@
+package conditional_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/intelux/gotomatic/conditional"
+	"github.com/intelux/gotomatic/conditional/conditionaltest"
+)
+
+func TestDebounceAsymmetricWithClock(t *testing.T) {
+	base := conditional.NewManualCondition(false)
+	clock := conditionaltest.NewFakeClock(time.Unix(0, 0))
+
+	debounced := conditional.DebounceAsymmetricWithClock(base, 10*time.Second, time.Second, clock)
+	defer debounced.Close()
+
+	base.Set(true)
+	waitForClockWaiters(t, clock, 1)
+
+	clock.Advance(time.Second)
+
+	select {
+	case <-debounced.Wait(true):
+		t.Fatal("the rise delay has not elapsed yet")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	clock.Advance(9 * time.Second)
+
+	select {
+	case err := <-debounced.Wait(true):
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("the debounced condition never reflected the rise")
+	}
+
+	base.Set(false)
+	waitForClockWaiters(t, clock, 1)
+
+	clock.Advance(time.Second)
+
+	select {
+	case err := <-debounced.Wait(false):
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("the debounced condition never reflected the fall after its shorter delay")
+	}
+}
@@ -4,7 +4,8 @@ import "time"
 
 type delayedCondition struct {
 	Condition
-	Delay        time.Duration
+	delayFor     func(satisfied bool) time.Duration
+	clock        Clock
 	subcondition Condition
 	done         chan struct{}
 }
@@ -13,10 +14,26 @@ type delayedCondition struct {
 // at least for the specified duration. The initial state of the passed-in
 // condition is copied without delay.
 func Delay(condition Condition, delay time.Duration) Condition {
+	return DelayWithClock(condition, delay, RealClock)
+}
+
+// DelayWithClock behaves like Delay, but lets the caller supply the Clock
+// used to schedule the delay. This is primarily useful in tests, where the
+// conditionaltest package's FakeClock lets the delay be advanced
+// deterministically instead of relying on real sleeps.
+func DelayWithClock(condition Condition, delay time.Duration, clock Clock) Condition {
+	return newDelayedCondition(condition, func(bool) time.Duration { return delay }, clock)
+}
+
+// newDelayedCondition is the shared implementation behind Delay and
+// DebounceAsymmetric: delayFor picks the hold time to apply once the
+// underlying condition settles on a given state.
+func newDelayedCondition(condition Condition, delayFor func(satisfied bool) time.Duration, clock Clock) Condition {
 	state, channel := condition.GetAndWaitChange()
 	c := &delayedCondition{
 		Condition:    NewManualCondition(state),
-		Delay:        delay,
+		delayFor:     delayFor,
+		clock:        clock,
 		subcondition: condition,
 		done:         make(chan struct{}),
 	}
@@ -42,57 +59,33 @@ func (condition *delayedCondition) Close() error {
 	return condition.Condition.Close()
 }
 
-type timer interface {
-	Wait() <-chan time.Time
-	Stop()
-}
-
-type realTimer struct {
-	timer *time.Timer
-}
-
-func (t realTimer) Wait() <-chan time.Time {
-	return t.timer.C
-}
-
-func (t realTimer) Stop() {
-	t.timer.Stop()
-}
-
-type foreverTimer struct {
-	channel chan time.Time
-}
-
-func (t foreverTimer) Wait() <-chan time.Time {
-	return t.channel
-}
-
-func (t foreverTimer) Stop() {
-	close(t.channel)
-}
-
 func (condition delayedCondition) waitChange(state bool, channel <-chan error) {
-	var timer timer = foreverTimer{
-		channel: make(chan time.Time),
-	}
+	var timer Timer
+	var timerChannel <-chan time.Time
 
 	for {
 		select {
 		case <-condition.done:
 			// The condition was closed.
-			timer.Stop()
+			if timer != nil {
+				timer.Stop()
+			}
 			return
 		case <-channel:
 			// The underlying condition changed, let's rewait and start a timer.
 			state, channel = condition.subcondition.GetAndWaitChange()
-			timer.Stop()
-			timer = realTimer{timer: time.NewTimer(condition.Delay)}
-		case <-timer.Wait():
+
+			if timer != nil {
+				timer.Stop()
+			}
+
+			timer = condition.clock.NewTimer(condition.delayFor(state))
+			timerChannel = timer.C()
+		case <-timerChannel:
 			// The timer expired. Let's apply the last recovered state.
 			condition.Condition.(*ManualCondition).Set(state)
-			timer = foreverTimer{
-				channel: make(chan time.Time),
-			}
+			timer = nil
+			timerChannel = nil
 		}
 	}
 }
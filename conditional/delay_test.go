@@ -0,0 +1,58 @@
+package conditional_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/intelux/gotomatic/conditional"
+	"github.com/intelux/gotomatic/conditional/conditionaltest"
+)
+
+// waitForClockWaiters busy-polls until clock has at least n pending timers,
+// so tests can synchronize with a goroutine that just scheduled one without
+// relying on a fixed sleep before calling Advance.
+func waitForClockWaiters(t *testing.T, clock *conditionaltest.FakeClock, n int) {
+	t.Helper()
+
+	deadline := time.Now().Add(time.Second)
+
+	for time.Now().Before(deadline) {
+		if clock.Waiters() >= n {
+			return
+		}
+
+		time.Sleep(time.Millisecond)
+	}
+
+	t.Fatalf("timed out waiting for %d fake timer(s)", n)
+}
+
+func TestDelayWithClock(t *testing.T) {
+	base := conditional.NewManualCondition(false)
+	clock := conditionaltest.NewFakeClock(time.Unix(0, 0))
+
+	delayed := conditional.DelayWithClock(base, time.Second, clock)
+	defer delayed.Close()
+
+	base.Set(true)
+	waitForClockWaiters(t, clock, 1)
+
+	clock.Advance(999 * time.Millisecond)
+
+	select {
+	case <-delayed.Wait(true):
+		t.Fatal("the delayed condition should not reflect the change before the full delay elapses")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	clock.Advance(time.Millisecond)
+
+	select {
+	case err := <-delayed.Wait(true):
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("the delayed condition never reflected the change")
+	}
+}
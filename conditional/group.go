@@ -0,0 +1,179 @@
+package conditional
+
+import (
+	"context"
+	"sync"
+)
+
+// GroupMode selects how a Group aggregates its children when exposed as a
+// single Condition via AsCondition.
+type GroupMode int
+
+const (
+	// All is satisfied when every child condition is satisfied (AND).
+	All GroupMode = iota
+
+	// Any is satisfied when at least one child condition is satisfied (OR).
+	Any
+)
+
+// Group aggregates several Conditions and lets callers wait for all or any
+// of them to reach a given state, or observe the aggregate as a single
+// Condition via AsCondition. This is what lets "trigger X when conditions
+// A, B, C are all satisfied for at least 5s" be expressed by combining a
+// Group with Delay.
+type Group struct {
+	lock       sync.Mutex
+	conditions []Condition
+	satisfied  []bool
+	cancels    []func()
+	changed    chan struct{}
+	closed     bool
+	all        *ManualCondition
+	any        *ManualCondition
+}
+
+// NewGroup creates a Group observing the given conditions.
+func NewGroup(conditions ...Condition) *Group {
+	g := &Group{
+		conditions: conditions,
+		satisfied:  make([]bool, len(conditions)),
+		cancels:    make([]func(), len(conditions)),
+		changed:    make(chan struct{}),
+		all:        NewManualCondition(len(conditions) == 0),
+		any:        NewManualCondition(false),
+	}
+
+	for i, condition := range conditions {
+		i := i
+		g.cancels[i] = condition.Register(ConditionStateObserverFunc(func(satisfied bool) {
+			g.onChange(i, satisfied)
+		}))
+	}
+
+	return g
+}
+
+func (g *Group) onChange(index int, satisfied bool) {
+	// g.all.Set and g.any.Set are applied while still holding g.lock, so
+	// that concurrent onChange calls from different children are fully
+	// serialized: a call can never compute its aggregate from a newer
+	// g.satisfied than the one whose Set it lands after.
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	g.satisfied[index] = satisfied
+
+	allSatisfied := true
+	anySatisfied := false
+
+	for _, s := range g.satisfied {
+		allSatisfied = allSatisfied && s
+		anySatisfied = anySatisfied || s
+	}
+
+	close(g.changed)
+	g.changed = make(chan struct{})
+
+	g.all.Set(allSatisfied)
+	g.any.Set(anySatisfied)
+}
+
+// WaitAll returns once every condition in the group has reached the
+// specified satisfied state, ctx is done (in which case ctx.Err() is
+// returned), or the group is closed.
+func (g *Group) WaitAll(ctx context.Context, satisfied bool) error {
+	_, err := g.wait(ctx, func(states []bool) (int, bool) {
+		for _, s := range states {
+			if s != satisfied {
+				return -1, false
+			}
+		}
+
+		return -1, true
+	})
+
+	return err
+}
+
+// WaitAny returns the index of the first condition found in the specified
+// satisfied state, once one exists, ctx is done (in which case ctx.Err() is
+// returned), or the group is closed.
+func (g *Group) WaitAny(ctx context.Context, satisfied bool) (int, error) {
+	return g.wait(ctx, func(states []bool) (int, bool) {
+		for i, s := range states {
+			if s == satisfied {
+				return i, true
+			}
+		}
+
+		return -1, false
+	})
+}
+
+func (g *Group) wait(ctx context.Context, match func([]bool) (int, bool)) (int, error) {
+	for {
+		g.lock.Lock()
+
+		if index, ok := match(g.satisfied); ok {
+			g.lock.Unlock()
+			return index, nil
+		}
+
+		if g.closed {
+			g.lock.Unlock()
+			return -1, ErrConditionClosed
+		}
+
+		changed := g.changed
+
+		g.lock.Unlock()
+
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return -1, ctx.Err()
+		}
+	}
+}
+
+// AsCondition exposes the group as a single Condition, satisfied according
+// to mode.
+func (g *Group) AsCondition(mode GroupMode) Condition {
+	if mode == Any {
+		return g.any
+	}
+
+	return g.all
+}
+
+// Close unregisters the group from all of its children and terminates its
+// aggregate conditions.
+//
+// Calling Close() twice or more has no effect.
+func (g *Group) Close() error {
+	g.lock.Lock()
+
+	if g.closed {
+		g.lock.Unlock()
+		return nil
+	}
+
+	g.closed = true
+	cancels := g.cancels
+	g.cancels = nil
+	close(g.changed)
+
+	g.lock.Unlock()
+
+	for _, cancel := range cancels {
+		if cancel != nil {
+			cancel()
+		}
+	}
+
+	g.all.Close()
+	g.any.Close()
+
+	return nil
+}
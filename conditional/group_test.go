@@ -0,0 +1,122 @@
+package conditional_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/intelux/gotomatic/conditional"
+)
+
+func TestGroup_Close_DoesNotPanic(t *testing.T) {
+	group := conditional.NewGroup(
+		conditional.NewManualCondition(false),
+		conditional.NewManualCondition(false),
+	)
+
+	if err := group.Close(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestGroup_WaitAll(t *testing.T) {
+	a := conditional.NewManualCondition(false)
+	b := conditional.NewManualCondition(false)
+	group := conditional.NewGroup(a, b)
+	defer group.Close()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- group.WaitAll(context.Background(), true)
+	}()
+
+	a.Set(true)
+
+	select {
+	case err := <-done:
+		t.Fatalf("WaitAll returned too early: %v", err)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	b.Set(true)
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("WaitAll never returned once all children were satisfied")
+	}
+}
+
+func TestGroup_WaitAny(t *testing.T) {
+	a := conditional.NewManualCondition(false)
+	b := conditional.NewManualCondition(false)
+	group := conditional.NewGroup(a, b)
+	defer group.Close()
+
+	type result struct {
+		index int
+		err   error
+	}
+
+	done := make(chan result, 1)
+	go func() {
+		index, err := group.WaitAny(context.Background(), true)
+		done <- result{index: index, err: err}
+	}()
+
+	b.Set(true)
+
+	select {
+	case r := <-done:
+		if r.err != nil {
+			t.Fatalf("unexpected error: %v", r.err)
+		}
+
+		if r.index != 1 {
+			t.Fatalf("expected index 1, got %d", r.index)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("WaitAny never returned")
+	}
+}
+
+func TestGroup_AsCondition(t *testing.T) {
+	a := conditional.NewManualCondition(false)
+	b := conditional.NewManualCondition(false)
+	group := conditional.NewGroup(a, b)
+	defer group.Close()
+
+	all := group.AsCondition(conditional.All)
+	any := group.AsCondition(conditional.Any)
+
+	a.Set(true)
+
+	select {
+	case err := <-any.Wait(true):
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("AsCondition(Any) never reflected a satisfied child")
+	}
+
+	select {
+	case <-all.Wait(true):
+		t.Fatal("AsCondition(All) should not be satisfied until every child is")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	b.Set(true)
+
+	select {
+	case err := <-all.Wait(true):
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("AsCondition(All) never reflected both children being satisfied")
+	}
+}
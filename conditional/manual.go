@@ -1,6 +1,7 @@
 package conditional
 
 import (
+	"context"
 	"sync"
 )
 
@@ -10,12 +11,36 @@ type Settable interface {
 	Set(satisfied bool)
 }
 
+// waiter tracks a single pending Wait/GetAndWaitChange call so that it can
+// be resolved either by a state change or by the cancellation of the
+// context it was created with, and removed from ManualCondition.channels in
+// both cases so a chatty, cancelling caller does not leak entries.
+//
+// channel is read exactly once, by the original Wait/WaitCtx/
+// GetAndWaitChange(Ctx) caller, and carries the outcome of the wait. done is
+// a separate signal, read only by watchCancel, that is closed whenever
+// channel has been resolved by Set() or Close(); keeping the two channels
+// distinct means watchCancel never races the real caller for channel's
+// single buffered value.
+type waiter struct {
+	channel chan error
+	done    chan struct{}
+}
+
+// registration wraps an observer registered via Register behind a unique
+// pointer, so unregister can find it again by identity even when the
+// observer itself (e.g. a ConditionStateObserverFunc) is not a comparable
+// value.
+type registration struct {
+	observer ConditionStateObserver
+}
+
 // ManualCondition is a condition that can be set or unset explicitely.
 type ManualCondition struct {
 	lock      sync.Mutex
 	satisfied bool
-	channels  []chan error
-	observers []ConditionStateObserver
+	channels  []*waiter
+	observers []*registration
 }
 
 // NewManualCondition instantiates a new ManualCondition in the specified
@@ -35,18 +60,27 @@ func NewManualCondition(satisfied bool) *ManualCondition {
 // If the condition is closed or the wait fails for whatever reason,
 // `ErrConditionClosed` is returned on the channel.
 func (c *ManualCondition) Wait(satisfied bool) <-chan error {
-	channel := make(chan error, 1)
+	return c.wait(context.Background(), satisfied)
+}
+
+// WaitCtx behaves like Wait, but also unblocks and returns ctx.Err() as soon
+// as ctx is done, without closing the condition itself.
+func (c *ManualCondition) WaitCtx(ctx context.Context, satisfied bool) error {
+	return <-c.wait(ctx, satisfied)
+}
 
+func (c *ManualCondition) wait(ctx context.Context, satisfied bool) <-chan error {
 	c.lock.Lock()
 	defer c.lock.Unlock()
 
 	if satisfied == c.satisfied {
+		channel := make(chan error, 1)
 		close(channel)
-	} else {
-		c.channels = append(c.channels, channel)
+
+		return channel
 	}
 
-	return channel
+	return c.registerWaiterLocked(ctx).channel
 }
 
 // GetAndWaitChange returns the current satisfied state of the condition as
@@ -55,14 +89,70 @@ func (c *ManualCondition) Wait(satisfied bool) <-chan error {
 // If the condition is closed or the wait fails for whatever reason,
 // `ErrConditionClosed` is returned on the channel.
 func (c *ManualCondition) GetAndWaitChange() (bool, <-chan error) {
-	channel := make(chan error, 1)
+	return c.getAndWaitChange(context.Background())
+}
 
+// GetAndWaitChangeCtx behaves like GetAndWaitChange, but also unblocks and
+// returns ctx.Err() as soon as ctx is done, without closing the condition
+// itself.
+func (c *ManualCondition) GetAndWaitChangeCtx(ctx context.Context) (bool, error) {
+	satisfied, channel := c.getAndWaitChange(ctx)
+
+	return satisfied, <-channel
+}
+
+func (c *ManualCondition) getAndWaitChange(ctx context.Context) (bool, <-chan error) {
 	c.lock.Lock()
 	defer c.lock.Unlock()
 
-	c.channels = append(c.channels, channel)
+	w := c.registerWaiterLocked(ctx)
+
+	return c.satisfied, w.channel
+}
+
+// registerWaiterLocked creates a new waiter, appends it to c.channels and,
+// if ctx can be cancelled, starts a goroutine that resolves the waiter with
+// ctx.Err() and removes it from c.channels as soon as ctx is done. c.lock
+// must be held by the caller.
+func (c *ManualCondition) registerWaiterLocked(ctx context.Context) *waiter {
+	w := &waiter{channel: make(chan error, 1), done: make(chan struct{})}
+
+	c.channels = append(c.channels, w)
+
+	if ctx != nil && ctx.Done() != nil {
+		go c.watchCancel(ctx, w)
+	}
 
-	return c.satisfied, channel
+	return w
+}
+
+func (c *ManualCondition) watchCancel(ctx context.Context, w *waiter) {
+	select {
+	case <-ctx.Done():
+		c.lock.Lock()
+		defer c.lock.Unlock()
+
+		if c.removeWaiterLocked(w) {
+			w.channel <- ctx.Err()
+			close(w.channel)
+			close(w.done)
+		}
+	case <-w.done:
+		// The waiter was resolved by Set() or Close(); nothing to clean up.
+	}
+}
+
+// removeWaiterLocked removes w from c.channels, returning whether it was
+// found (i.e. was still pending). c.lock must be held by the caller.
+func (c *ManualCondition) removeWaiterLocked(w *waiter) bool {
+	for i, candidate := range c.channels {
+		if candidate == w {
+			c.channels = append(c.channels[:i], c.channels[i+1:]...)
+			return true
+		}
+	}
+
+	return false
 }
 
 // Close terminates the condition.
@@ -76,9 +166,10 @@ func (c *ManualCondition) Close() error {
 	c.lock.Lock()
 	defer c.lock.Unlock()
 
-	for _, channel := range c.channels {
-		channel <- ErrConditionClosed
-		close(channel)
+	for _, w := range c.channels {
+		w.channel <- ErrConditionClosed
+		close(w.channel)
+		close(w.done)
 	}
 
 	c.channels = nil
@@ -97,18 +188,21 @@ func (c *ManualCondition) Register(observer ConditionStateObserver) func() {
 
 	observer.OnChange(c.satisfied)
 
-	c.observers = append(c.observers, observer)
+	reg := &registration{observer: observer}
+	c.observers = append(c.observers, reg)
 
-	return func() { c.unregister(observer) }
+	return func() { c.unregister(reg) }
 }
 
-// Unregister a callback for changes.
-func (c *ManualCondition) unregister(observer ConditionStateObserver) {
+// unregister removes reg, found by pointer identity rather than by
+// comparing the wrapped observer (which may not be a comparable value,
+// e.g. a ConditionStateObserverFunc).
+func (c *ManualCondition) unregister(reg *registration) {
 	c.lock.Lock()
 	defer c.lock.Unlock()
 
-	for i, ob := range c.observers {
-		if ob == observer {
+	for i, candidate := range c.observers {
+		if candidate == reg {
 			c.observers = append(c.observers[:i], c.observers[i+1:]...)
 			return
 		}
@@ -126,14 +220,15 @@ func (c *ManualCondition) Set(satisfied bool) {
 	if satisfied != c.satisfied {
 		c.satisfied = satisfied
 
-		for _, channel := range c.channels {
-			close(channel)
+		for _, w := range c.channels {
+			close(w.channel)
+			close(w.done)
 		}
 
-		c.channels = make([]chan error, 0, 0)
+		c.channels = nil
 
-		for _, observer := range c.observers {
-			observer.OnChange(satisfied)
+		for _, reg := range c.observers {
+			reg.observer.OnChange(satisfied)
 		}
 	}
 }
@@ -0,0 +1,201 @@
+package conditional
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+// waitForPendingWaiters busy-polls until c has at least n pending waiters,
+// so tests can synchronize with a goroutine that just called Wait/WaitCtx
+// without relying on a fixed sleep.
+func waitForPendingWaiters(t *testing.T, c *ManualCondition, n int) {
+	t.Helper()
+
+	deadline := time.Now().Add(time.Second)
+
+	for time.Now().Before(deadline) {
+		c.lock.Lock()
+		count := len(c.channels)
+		c.lock.Unlock()
+
+		if count >= n {
+			return
+		}
+
+		time.Sleep(time.Millisecond)
+	}
+
+	t.Fatalf("timed out waiting for %d pending waiter(s)", n)
+}
+
+func TestManualCondition_Wait(t *testing.T) {
+	c := NewManualCondition(false)
+
+	select {
+	case err := <-c.Wait(false):
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	default:
+		t.Fatal("Wait on the current state should not block")
+	}
+
+	channel := c.Wait(true)
+
+	select {
+	case <-channel:
+		t.Fatal("Wait on a different state should block until Set")
+	default:
+	}
+
+	c.Set(true)
+
+	select {
+	case err := <-channel:
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Wait channel was not resolved after Set")
+	}
+}
+
+func TestManualCondition_WaitCtx_Cancel(t *testing.T) {
+	c := NewManualCondition(false)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- c.WaitCtx(ctx, true)
+	}()
+
+	waitForPendingWaiters(t, c, 1)
+	cancel()
+
+	select {
+	case err := <-errCh:
+		if err != context.Canceled {
+			t.Fatalf("expected context.Canceled, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("WaitCtx did not return after context cancellation")
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if len(c.channels) != 0 {
+		t.Fatalf("expected the cancelled waiter to be removed, got %d remaining", len(c.channels))
+	}
+}
+
+func TestManualCondition_GetAndWaitChangeCtx(t *testing.T) {
+	c := NewManualCondition(false)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	type result struct {
+		state bool
+		err   error
+	}
+
+	done := make(chan result, 1)
+
+	go func() {
+		state, err := c.GetAndWaitChangeCtx(ctx)
+		done <- result{state: state, err: err}
+	}()
+
+	waitForPendingWaiters(t, c, 1)
+	c.Set(true)
+
+	select {
+	case r := <-done:
+		if r.err != nil {
+			t.Fatalf("unexpected error: %v", r.err)
+		}
+
+		if r.state != false {
+			t.Fatalf("expected the state captured at call time (false), got %v", r.state)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("GetAndWaitChangeCtx did not return after Set")
+	}
+}
+
+// TestManualCondition_WaitCtx_RaceWithClose guards against a watchCancel
+// goroutine racing the real WaitCtx caller for the single buffered value on
+// a waiter's channel: if watchCancel's bookkeeping select ever reads from
+// that same channel, the caller is left to read a zero value off the
+// now-closed channel and wrongly sees a nil error instead of
+// ErrConditionClosed/ctx.Err().
+func TestManualCondition_WaitCtx_RaceWithClose(t *testing.T) {
+	for i := 0; i < 2000; i++ {
+		c := NewManualCondition(false)
+		ctx, cancel := context.WithCancel(context.Background())
+
+		errCh := make(chan error, 1)
+		go func() {
+			errCh <- c.WaitCtx(ctx, true)
+		}()
+
+		waitForPendingWaiters(t, c, 1)
+
+		var wg sync.WaitGroup
+		wg.Add(2)
+		go func() { defer wg.Done(); cancel() }()
+		go func() { defer wg.Done(); c.Close() }()
+		wg.Wait()
+
+		if err := <-errCh; err == nil {
+			t.Fatalf("iteration %d: WaitCtx returned nil racing against Close()/cancel()", i)
+		}
+	}
+}
+
+// TestManualCondition_GetAndWaitChangeCtx_RaceWithClose is the
+// GetAndWaitChangeCtx analog of TestManualCondition_WaitCtx_RaceWithClose.
+func TestManualCondition_GetAndWaitChangeCtx_RaceWithClose(t *testing.T) {
+	for i := 0; i < 2000; i++ {
+		c := NewManualCondition(false)
+		ctx, cancel := context.WithCancel(context.Background())
+
+		errCh := make(chan error, 1)
+		go func() {
+			_, err := c.GetAndWaitChangeCtx(ctx)
+			errCh <- err
+		}()
+
+		waitForPendingWaiters(t, c, 1)
+
+		var wg sync.WaitGroup
+		wg.Add(2)
+		go func() { defer wg.Done(); cancel() }()
+		go func() { defer wg.Done(); c.Close() }()
+		wg.Wait()
+
+		if err := <-errCh; err == nil {
+			t.Fatalf("iteration %d: GetAndWaitChangeCtx returned nil racing against Close()/cancel()", i)
+		}
+	}
+}
+
+func TestManualCondition_Close(t *testing.T) {
+	c := NewManualCondition(false)
+	channel := c.Wait(true)
+
+	if err := c.Close(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	select {
+	case err := <-channel:
+		if err != ErrConditionClosed {
+			t.Fatalf("expected ErrConditionClosed, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Wait channel was not resolved after Close")
+	}
+}
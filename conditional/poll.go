@@ -0,0 +1,122 @@
+package conditional
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"time"
+)
+
+// ErrStopPolling can be returned by a Poll predicate to stop polling for
+// good. It is never surfaced on the Errors() channel.
+var ErrStopPolling = errors.New("conditional: stop polling")
+
+// PollCondition is the Condition returned by Poll. In addition to the usual
+// Condition methods, it exposes the errors returned by its predicate.
+type PollCondition interface {
+	Condition
+
+	// Errors returns a channel on which errors returned by the predicate
+	// are published. It is never closed.
+	Errors() <-chan error
+}
+
+type pollCondition struct {
+	*ManualCondition
+	cancel context.CancelFunc
+	done   chan struct{}
+	errors chan error
+}
+
+// Poll returns a Condition that polls predicate on a jittered interval and
+// reflects its boolean result.
+//
+// Between two calls to predicate, Poll sleeps for
+// `interval + rand.Float64() * jitterFactor * interval` (the jitter term is
+// skipped when jitterFactor is not strictly positive). This mirrors the
+// Kubernetes `wait.JitterUntil`/`PollUntil` pattern and lets callers express
+// "the condition is satisfied when this HTTP/DB check passes".
+//
+// Errors returned by predicate do not stop polling and are instead
+// published on Errors(), unless predicate returns ErrStopPolling, in which
+// case polling stops for good and the condition is left in its last known
+// state. Publishing an error blocks until it is received from Errors() or
+// the condition is closed, so polling pauses while an error is unread.
+func Poll(predicate func(context.Context) (bool, error), interval time.Duration, jitterFactor float64) PollCondition {
+	return PollWithClock(predicate, interval, jitterFactor, RealClock)
+}
+
+// PollWithClock behaves like Poll, but lets the caller supply the Clock used
+// to schedule the interval between two predicate calls. This is primarily
+// useful in tests, where the conditionaltest package's FakeClock lets the
+// interval be advanced deterministically instead of relying on real sleeps.
+func PollWithClock(predicate func(context.Context) (bool, error), interval time.Duration, jitterFactor float64, clock Clock) PollCondition {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	c := &pollCondition{
+		ManualCondition: NewManualCondition(false),
+		cancel:          cancel,
+		done:            make(chan struct{}),
+		errors:          make(chan error),
+	}
+
+	go c.poll(ctx, predicate, interval, jitterFactor, clock)
+
+	return c
+}
+
+// Errors returns a channel on which errors returned by the predicate are
+// published. It is never closed.
+func (c *pollCondition) Errors() <-chan error {
+	return c.errors
+}
+
+// Close terminates the condition, cancelling any in-flight predicate call
+// and stopping the polling goroutine.
+//
+// Calling Close() twice or more has no effect.
+func (c *pollCondition) Close() error {
+	if c.cancel != nil {
+		c.cancel()
+		<-c.done
+		c.cancel = nil
+	}
+
+	return c.ManualCondition.Close()
+}
+
+func (c *pollCondition) poll(ctx context.Context, predicate func(context.Context) (bool, error), interval time.Duration, jitterFactor float64, clock Clock) {
+	defer close(c.done)
+
+	for {
+		satisfied, err := predicate(ctx)
+
+		switch {
+		case errors.Is(err, ErrStopPolling):
+			return
+		case err != nil:
+			select {
+			case c.errors <- err:
+			case <-ctx.Done():
+				return
+			}
+		default:
+			c.ManualCondition.Set(satisfied)
+		}
+
+		sleep := interval
+
+		if jitterFactor > 0.0 {
+			sleep += time.Duration(rand.Float64() * jitterFactor * float64(interval))
+		}
+
+		timer := clock.NewTimer(sleep)
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C():
+		}
+	}
+}
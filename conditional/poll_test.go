@@ -0,0 +1,76 @@
+package conditional
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestPoll_ReflectsPredicate(t *testing.T) {
+	results := make(chan bool, 2)
+	results <- false
+	results <- true
+
+	c := Poll(func(context.Context) (bool, error) {
+		select {
+		case satisfied := <-results:
+			return satisfied, nil
+		default:
+			return true, nil
+		}
+	}, time.Millisecond, 0)
+	defer c.Close()
+
+	select {
+	case err := <-c.Wait(true):
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Poll never reflected a satisfied predicate")
+	}
+}
+
+func TestPoll_SurfacesErrors(t *testing.T) {
+	wantErr := errors.New("boom")
+
+	c := Poll(func(context.Context) (bool, error) {
+		return false, wantErr
+	}, time.Millisecond, 0)
+	defer c.Close()
+
+	select {
+	case err := <-c.Errors():
+		if err != wantErr {
+			t.Fatalf("expected %v, got %v", wantErr, err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("the predicate error was never published")
+	}
+}
+
+func TestPoll_StopsOnErrStopPolling(t *testing.T) {
+	calls := make(chan struct{}, 8)
+
+	c := Poll(func(context.Context) (bool, error) {
+		calls <- struct{}{}
+		return false, ErrStopPolling
+	}, time.Millisecond, 0)
+
+	select {
+	case <-calls:
+	case <-time.After(time.Second):
+		t.Fatal("the predicate was never called")
+	}
+
+	if err := c.Close(); err != nil {
+		t.Fatalf("unexpected error closing: %v", err)
+	}
+
+	// Give a would-be second call a chance to happen before asserting it
+	// didn't: Close() only returns once the polling goroutine has stopped.
+	if len(calls) != 0 {
+		t.Fatalf("expected polling to stop after ErrStopPolling, got %d extra call(s)", len(calls))
+	}
+}
@@ -0,0 +1,103 @@
+package conditional
+
+import "time"
+
+type throttledCondition struct {
+	Condition
+	min          time.Duration
+	clock        Clock
+	subcondition Condition
+	done         chan struct{}
+}
+
+// Throttle returns a Condition that forwards the underlying condition's
+// changes immediately, then suppresses further changes until min has
+// elapsed since the last emitted change, coalescing to the underlying
+// condition's state at that point. This is a common pattern for hysteresis
+// in home-automation-style rules, where Delay is awkward to use when the
+// first change must be reflected right away.
+func Throttle(condition Condition, min time.Duration) Condition {
+	return ThrottleWithClock(condition, min, RealClock)
+}
+
+// ThrottleWithClock behaves like Throttle, but lets the caller supply the
+// Clock used to schedule the throttling window.
+func ThrottleWithClock(condition Condition, min time.Duration, clock Clock) Condition {
+	state, channel := condition.GetAndWaitChange()
+	c := &throttledCondition{
+		Condition:    NewManualCondition(state),
+		min:          min,
+		clock:        clock,
+		subcondition: condition,
+		done:         make(chan struct{}),
+	}
+
+	go c.waitChange(state, channel)
+
+	return c
+}
+
+// Close terminates the condition.
+//
+// Any pending wait on one of the returned channels via Wait() or
+// GetAndWaitChange() will be unblocked.
+//
+// Calling Close() twice or more has no effect.
+func (condition *throttledCondition) Close() error {
+	if condition.done != nil {
+		close(condition.done)
+		condition.done = nil
+	}
+
+	condition.subcondition.Close()
+	return condition.Condition.Close()
+}
+
+func (condition throttledCondition) waitChange(state bool, channel <-chan error) {
+	var timer Timer
+	var timerChannel <-chan time.Time
+	pending := false
+	emitted := state
+
+	for {
+		select {
+		case <-condition.done:
+			// The condition was closed.
+			if timer != nil {
+				timer.Stop()
+			}
+			return
+		case <-channel:
+			// The underlying condition changed.
+			state, channel = condition.subcondition.GetAndWaitChange()
+
+			if timer == nil {
+				// Not currently throttling: forward right away and open a
+				// suppression window.
+				if state != emitted {
+					emitted = state
+					condition.Condition.(*ManualCondition).Set(state)
+					timer = condition.clock.NewTimer(condition.min)
+					timerChannel = timer.C()
+				}
+			} else {
+				// Already throttling: remember the latest state to
+				// coalesce to once the window expires.
+				pending = true
+			}
+		case <-timerChannel:
+			// The suppression window expired.
+			timer = nil
+			timerChannel = nil
+
+			if pending && state != emitted {
+				emitted = state
+				condition.Condition.(*ManualCondition).Set(state)
+				timer = condition.clock.NewTimer(condition.min)
+				timerChannel = timer.C()
+			}
+
+			pending = false
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package conditional_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/intelux/gotomatic/conditional"
+	"github.com/intelux/gotomatic/conditional/conditionaltest"
+)
+
+func TestThrottleWithClock(t *testing.T) {
+	base := conditional.NewManualCondition(false)
+	clock := conditionaltest.NewFakeClock(time.Unix(0, 0))
+
+	throttled := conditional.ThrottleWithClock(base, time.Second, clock)
+	defer throttled.Close()
+
+	base.Set(true)
+
+	select {
+	case err := <-throttled.Wait(true):
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Throttle should forward the first change immediately")
+	}
+
+	waitForClockWaiters(t, clock, 1)
+
+	base.Set(false)
+
+	select {
+	case <-throttled.Wait(false):
+		t.Fatal("Throttle should suppress changes within the throttling window")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	clock.Advance(time.Second)
+
+	select {
+	case err := <-throttled.Wait(false):
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Throttle never coalesced to the latest state once the window expired")
+	}
+}
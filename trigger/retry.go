@@ -0,0 +1,105 @@
+package trigger
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math/rand"
+	"time"
+
+	"github.com/intelux/gotomatic/conditional"
+)
+
+// BackoffOptions configures the exponential backoff used by Retry.
+type BackoffOptions struct {
+	// Initial is the delay before the first retry.
+	Initial time.Duration
+
+	// Max is the upper bound to which Factor grows the delay between two
+	// retries. Initial is used as is, even above Max, and Jitter is added on
+	// top of the capped delay.
+	Max time.Duration
+
+	// Factor is applied to the delay after each failed attempt.
+	Factor float64
+
+	// Jitter, when strictly positive, adds up to `Jitter * delay` of random
+	// noise to each computed delay.
+	Jitter float64
+
+	// Steps bounds the number of attempts. 0 means unlimited.
+	Steps int
+
+	// Context, when set, cancels any pending retry.
+	Context context.Context
+
+	// Clock, when set, is used to schedule the delay between two attempts
+	// instead of the real clock. This is primarily useful in tests, where
+	// the conditionaltest package's FakeClock lets the delay be advanced
+	// deterministically instead of relying on real sleeps.
+	Clock conditional.Clock
+}
+
+type retryTrigger struct {
+	inner Trigger
+	opts  BackoffOptions
+}
+
+// Retry decorates inner so that a failing run is retried with exponential
+// backoff until it succeeds, opts.Steps attempts have been made, or
+// opts.Context is done.
+//
+// This lets callers make flaky side effects (webhooks, shell commands)
+// resilient without hand-writing a retry loop around every TriggerFunc.
+func Retry(inner Trigger, opts BackoffOptions) Trigger {
+	return retryTrigger{inner: inner, opts: opts}
+}
+
+func (t retryTrigger) run(w io.Writer, name string, state bool) error {
+	ctx := t.opts.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	clock := t.opts.Clock
+	if clock == nil {
+		clock = conditional.RealClock
+	}
+
+	duration := t.opts.Initial
+	var lastErr error
+
+	for attempt := 1; ; attempt++ {
+		lastErr = t.inner.run(w, name, state)
+		if lastErr == nil {
+			return nil
+		}
+
+		if t.opts.Steps > 0 && attempt >= t.opts.Steps {
+			return fmt.Errorf("trigger: giving up after %d attempt(s): %w", attempt, lastErr)
+		}
+
+		sleep := duration
+
+		if t.opts.Jitter > 0.0 {
+			sleep += time.Duration(rand.Float64() * t.opts.Jitter * float64(duration))
+		}
+
+		timer := clock.NewTimer(sleep)
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return fmt.Errorf("trigger: retry cancelled after %d attempt(s): %w", attempt, ctx.Err())
+		case <-timer.C():
+		}
+
+		if duration < t.opts.Max {
+			duration = time.Duration(float64(duration) * t.opts.Factor)
+
+			if duration > t.opts.Max {
+				duration = t.opts.Max
+			}
+		}
+	}
+}
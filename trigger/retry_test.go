@@ -0,0 +1,88 @@
+package trigger
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+)
+
+func TestRetry_SucceedsAfterFailures(t *testing.T) {
+	attempts := 0
+
+	inner := TriggerFunc(func(w io.Writer, name string, state bool) error {
+		attempts++
+
+		if attempts < 3 {
+			return errors.New("not yet")
+		}
+
+		return nil
+	})
+
+	retry := Retry(inner, BackoffOptions{
+		Initial: time.Millisecond,
+		Max:     time.Millisecond,
+		Factor:  1,
+	})
+
+	if err := Run(retry, io.Discard, "test", true); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if attempts != 3 {
+		t.Fatalf("expected 3 attempts, got %d", attempts)
+	}
+}
+
+func TestRetry_GivesUpAfterSteps(t *testing.T) {
+	attempts := 0
+	wantErr := errors.New("always fails")
+
+	inner := TriggerFunc(func(w io.Writer, name string, state bool) error {
+		attempts++
+		return wantErr
+	})
+
+	retry := Retry(inner, BackoffOptions{
+		Initial: time.Millisecond,
+		Max:     time.Millisecond,
+		Factor:  1,
+		Steps:   2,
+	})
+
+	err := Run(retry, io.Discard, "test", true)
+	if err == nil {
+		t.Fatal("expected an error after exhausting the retry budget")
+	}
+
+	if !errors.Is(err, wantErr) {
+		t.Fatalf("expected the final error to wrap %v, got %v", wantErr, err)
+	}
+
+	if attempts != 2 {
+		t.Fatalf("expected exactly 2 attempts, got %d", attempts)
+	}
+}
+
+func TestRetry_CancelledByContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	inner := TriggerFunc(func(w io.Writer, name string, state bool) error {
+		return errors.New("always fails")
+	})
+
+	retry := Retry(inner, BackoffOptions{
+		Initial: time.Hour,
+		Max:     time.Hour,
+		Factor:  1,
+		Context: ctx,
+	})
+
+	err := Run(retry, io.Discard, "test", true)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected the error to wrap context.Canceled, got %v", err)
+	}
+}
@@ -0,0 +1,14 @@
+package trigger
+
+import "io"
+
+// Trigger represents a side effect run in reaction to a condition's
+// satisfied state.
+type Trigger interface {
+	run(w io.Writer, name string, state bool) error
+}
+
+// Run invokes t, writing any output to w.
+func Run(t Trigger, w io.Writer, name string, state bool) error {
+	return t.run(w, name, state)
+}